@@ -3,6 +3,12 @@
 package rebirth
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -11,7 +17,10 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"runtime"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 )
@@ -28,6 +37,64 @@ const (
 	STATE_TERMINATE
 )
 
+// SOCKET_ORDER_ENV_KEY carries the name@addr listener order across fork so
+// the child can map inherited file descriptors back to the right listener.
+const SOCKET_ORDER_ENV_KEY = "GO_REBIRTH_SOCKET_ORDER"
+
+// runningServers tracks every RebirthServer created in this process, in
+// construction order, so that a single fork can hand every registered
+// listener of every server over to the child in one exec. It's a slice
+// rather than a map keyed by address because NewServer's addr argument
+// isn't necessarily resolved yet: ListenAndServe/ListenAndServeTLS only
+// default "" to ":http"/":https" after construction, so two servers both
+// constructed with NewServer("", ...) would otherwise collide on the same
+// map key and silently overwrite each other's registry entry.
+var (
+	serversMu      sync.Mutex
+	runningServers []*RebirthServer
+)
+
+// listenerSpec is the (network, addr) a named listener should bind to the
+// first time it isn't inherited from a parent process.
+type listenerSpec struct {
+	network string
+	addr    string
+	tls     bool
+	// handler, if set, is served on this listener instead of svr.Handler
+	// (used by StartAdmin's separate admin mux).
+	handler http.Handler
+}
+
+// RestartStrategy selects how a listener survives a fork.
+type RestartStrategy int
+
+const (
+	// StrategyDupFD passes the bound listener to the child via
+	// cmd.ExtraFiles; the child reconstructs it with net.FileListener.
+	StrategyDupFD RestartStrategy = iota
+	// StrategyReusePort sets SO_REUSEPORT (and SO_REUSEADDR) on the
+	// socket so the child simply calls net.Listen on the same address
+	// instead of inheriting a file descriptor, avoiding the
+	// single-accept-queue thundering herd that duplicated FDs can cause
+	// on Linux.
+	StrategyReusePort
+)
+
+// RestartMode selects how cautiously fork hands control to the child.
+type RestartMode int
+
+const (
+	// ModeSingleExec is the original behavior: fork the child and trust
+	// it to come up; the parent never rolls back.
+	ModeSingleExec RestartMode = iota
+	// ModeDoubleExec waits up to DoubleExecTimeout for the child to
+	// report readiness (by sending SIGUSR2, the same signal used for the
+	// single-exec readiness handshake). If the child exits or the
+	// timeout elapses first, the parent rolls back hasRunFork and keeps
+	// serving instead of being left with no child to hand off to.
+	ModeDoubleExec
+)
+
 type RebirthServer struct {
 	http.Server
 
@@ -38,6 +105,55 @@ type RebirthServer struct {
 	wg         *sync.WaitGroup
 	state      uint8
 	mutex      *sync.Mutex
+
+	// SignalHooks holds user-registered callbacks keyed by PRE_SIGNAL/POST_SIGNAL
+	// and then by the signal they should fire around.
+	SignalHooks map[int]map[os.Signal][]func()
+	// HookableSignals is the set of signals acceptProcessSign listens for.
+	// Defaults to SIGUSR1 and SIGUSR2.
+	HookableSignals []os.Signal
+
+	// HammerTime bounds how long a graceful Shutdown waits for in-flight
+	// connections to finish before the remaining ones are force-closed.
+	HammerTime time.Duration
+
+	conns   map[net.Conn]struct{}
+	connsMu *sync.Mutex
+
+	// sigWaiters holds one-shot waiters registered via waitForSignal,
+	// e.g. so superviseFork can wait for a single readiness signal
+	// without leaking a permanent entry into SignalHooks on every fork.
+	sigWaiters map[os.Signal][]chan struct{}
+
+	listeners     map[string]*RebirthListener
+	listenerSpecs map[string]listenerSpec
+	listenerOrder []string
+
+	tlsCertFile string
+	tlsKeyFile  string
+	tlsCert     atomic.Value // holds *tls.Certificate
+
+	// RestartStrategy picks how listeners are carried across a fork.
+	// Defaults to StrategyDupFD.
+	RestartStrategy RestartStrategy
+
+	// RestartMode picks how fork supervises the child it starts.
+	// Defaults to ModeSingleExec.
+	RestartMode RestartMode
+	// DoubleExecTimeout bounds how long ModeDoubleExec waits for the
+	// child to report readiness before rolling the fork back.
+	DoubleExecTimeout time.Duration
+
+	// AdminToken guards the mutating admin endpoints (/rebirth/restart,
+	// /rebirth/shutdown): callers must present an
+	// HMAC-SHA256(AdminToken, request path) hex digest in the
+	// X-Rebirth-Token header. Left empty, those endpoints are disabled.
+	AdminToken string
+	// AdminAddr is set to the admin listener's actual address once
+	// bindListeners has bound it (i.e. after Serve runs).
+	AdminAddr string
+
+	startedAt time.Time
 }
 
 func NewServer(addr string, handler http.Handler) (svr *RebirthServer) {
@@ -49,13 +165,105 @@ func NewServer(addr string, handler http.Handler) (svr *RebirthServer) {
 		sigChan:    make(chan os.Signal),
 		state:      STATE_INIT,
 		mutex:      new(sync.Mutex),
+		SignalHooks: map[int]map[os.Signal][]func(){
+			PRE_SIGNAL:  map[os.Signal][]func(){},
+			POST_SIGNAL: map[os.Signal][]func(){},
+		},
+		HookableSignals: []os.Signal{
+			syscall.SIGUSR1,
+			syscall.SIGUSR2,
+		},
+		HammerTime: 60 * time.Second,
+		conns:      map[net.Conn]struct{}{},
+		connsMu:    new(sync.Mutex),
+		sigWaiters: map[os.Signal][]chan struct{}{},
+
+		listeners:     map[string]*RebirthListener{},
+		listenerSpecs: map[string]listenerSpec{},
+
+		RestartStrategy:   StrategyDupFD,
+		RestartMode:       ModeSingleExec,
+		DoubleExecTimeout: 10 * time.Second,
+		startedAt:         time.Now(),
 	}
 	svr.Server.Addr = addr
 	svr.Server.MaxHeaderBytes = http.DefaultMaxHeaderBytes
 	svr.Server.Handler = handler
+
+	serversMu.Lock()
+	runningServers = append(runningServers, svr)
+	serversMu.Unlock()
+
 	return
 }
 
+// RegisterListener adds another listener, bound to addr over network (e.g.
+// "tcp"), that this server will serve svr.Handler on once Serve/fork up
+// it. name must be unique within the server and is the key used to carry
+// the listener's file descriptor across a fork via SOCKET_ORDER_ENV_KEY.
+func (svr *RebirthServer) RegisterListener(name, addr, network string) error {
+	return svr.registerListener(name, addr, network, nil)
+}
+
+// registerListener is the shared implementation behind RegisterListener and
+// StartAdmin. handler, if non-nil, is served on this listener instead of
+// svr.Handler.
+func (svr *RebirthServer) registerListener(name, addr, network string, handler http.Handler) error {
+	svr.mutex.Lock()
+	defer svr.mutex.Unlock()
+
+	if _, exists := svr.listenerSpecs[name]; exists {
+		return fmt.Errorf("rebirth: listener %q already registered", name)
+	}
+
+	svr.listenerSpecs[name] = listenerSpec{network: network, addr: addr, handler: handler}
+	svr.listenerOrder = append(svr.listenerOrder, name)
+	return nil
+}
+
+// RegisterSignalHook registers fn to run either before (PRE_SIGNAL) or
+// after (POST_SIGNAL) the built-in handling of sig. sig must also be part
+// of svr.HookableSignals for the hook to ever fire.
+func (svr *RebirthServer) RegisterSignalHook(when int, sig os.Signal, fn func()) error {
+	if when != PRE_SIGNAL && when != POST_SIGNAL {
+		return errors.New("rebirth: invalid signal hook time")
+	}
+	svr.SignalHooks[when][sig] = append(svr.SignalHooks[when][sig], fn)
+	return nil
+}
+
+func (svr *RebirthServer) runSignalHooks(when int, sig os.Signal) {
+	for _, fn := range svr.SignalHooks[when][sig] {
+		fn()
+	}
+}
+
+// waitForSignal returns a channel that fires exactly once, the next time
+// sig is received, then forgets itself. Unlike RegisterSignalHook, which
+// has no deregistration mechanism, this is safe to call on every fork
+// without accumulating state over the life of a long-running process.
+func (svr *RebirthServer) waitForSignal(sig os.Signal) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	svr.mutex.Lock()
+	svr.sigWaiters[sig] = append(svr.sigWaiters[sig], ch)
+	svr.mutex.Unlock()
+
+	return ch
+}
+
+// notifySignalWaiters wakes and clears every waitForSignal waiter for sig.
+func (svr *RebirthServer) notifySignalWaiters(sig os.Signal) {
+	svr.mutex.Lock()
+	waiters := svr.sigWaiters[sig]
+	delete(svr.sigWaiters, sig)
+	svr.mutex.Unlock()
+
+	for _, ch := range waiters {
+		ch <- struct{}{}
+	}
+}
+
 func ListenAndServe(addr string, handler http.Handler) error {
 	svr := NewServer(addr, handler)
 	return svr.ListenAndServe()
@@ -66,51 +274,222 @@ func (svr *RebirthServer) ListenAndServe() error {
 	if addr == "" {
 		addr = ":http"
 	}
+	svr.Addr = addr
 
-	ln, err := svr.getListener(addr)
-	if err != nil {
+	if _, exists := svr.listenerSpecs["http"]; !exists {
+		svr.listenerSpecs["http"] = listenerSpec{network: "tcp", addr: addr}
+		svr.listenerOrder = append(svr.listenerOrder, "http")
+	}
+
+	return svr.Serve()
+}
+
+// ListenAndServeTLS serves svr.Handler over TLS on an "https" listener,
+// loading certFile/keyFile up front and again on every SIGHUP so operators
+// can rotate certificates without dropping connections or forking.
+func (svr *RebirthServer) ListenAndServeTLS(certFile, keyFile string) error {
+	addr := svr.Addr
+	if addr == "" {
+		addr = ":https"
+	}
+	svr.Addr = addr
+
+	svr.tlsCertFile = certFile
+	svr.tlsKeyFile = keyFile
+	if err := svr.reloadCertificate(); err != nil {
 		return err
 	}
 
-	svr.ln = newListener(ln, svr)
+	if _, exists := svr.listenerSpecs["https"]; !exists {
+		svr.listenerSpecs["https"] = listenerSpec{network: "tcp", addr: addr, tls: true}
+		svr.listenerOrder = append(svr.listenerOrder, "https")
+	}
+
+	svr.addHookableSignal(syscall.SIGHUP)
 
 	return svr.Serve()
 }
 
+// addHookableSignal adds sig to HookableSignals if it isn't already there.
+func (svr *RebirthServer) addHookableSignal(sig os.Signal) {
+	for _, s := range svr.HookableSignals {
+		if s == sig {
+			return
+		}
+	}
+	svr.HookableSignals = append(svr.HookableSignals, sig)
+}
+
+// reloadCertificate re-reads svr.tlsCertFile/tlsKeyFile from disk and
+// atomically swaps the certificate in-flight connections pick up next
+// handshake.
+func (svr *RebirthServer) reloadCertificate() error {
+	cert, err := tls.LoadX509KeyPair(svr.tlsCertFile, svr.tlsKeyFile)
+	if err != nil {
+		return err
+	}
+	svr.tlsCert.Store(&cert)
+	return nil
+}
+
+func (svr *RebirthServer) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, _ := svr.tlsCert.Load().(*tls.Certificate)
+	if cert == nil {
+		return nil, errors.New("rebirth: no TLS certificate loaded")
+	}
+	return cert, nil
+}
+
 func (svr *RebirthServer) Serve() (err error) {
+	if err = svr.bindListeners(); err != nil {
+		return err
+	}
+
 	go svr.acceptProcessSign()
+	svr.setState(STATE_RUNNING)
+
+	errs := make(chan error, len(svr.listenerOrder))
+	for _, name := range svr.listenerOrder {
+		ln := svr.listeners[name]
+		handler := svr.listenerSpecs[name].handler
+		go func(ln *RebirthListener, handler http.Handler) {
+			if handler != nil {
+				errs <- http.Serve(ln, handler)
+				return
+			}
+			errs <- svr.Server.Serve(ln)
+		}(ln, handler)
+	}
+
 	if svr.hasRebirth {
+		// Every listener is bound and being served: tell the parent it is
+		// safe to stop accepting new connections.
 		syscall.Kill(syscall.Getppid(), syscall.SIGUSR2)
 	}
 
-	svr.setState(STATE_RUNNING)
-	err = svr.Server.Serve(svr.ln)
+	err = <-errs
 
 	svr.wg.Wait()
 	svr.setState(STATE_TERMINATE)
 	return
 }
 
-func (svr *RebirthServer) getListener(addr string) (l net.Listener, err error) {
-	if svr.hasRebirth {
-		//
-		f := os.NewFile(3, "")
-		l, err = net.FileListener(f)
-		if err != nil {
-			return
+// bindListeners binds (or, after a fork, inherits) every listener
+// registered via RegisterListener/ListenAndServe that isn't bound yet.
+func (svr *RebirthServer) bindListeners() error {
+	for _, name := range svr.listenerOrder {
+		if _, bound := svr.listeners[name]; bound {
+			continue
 		}
-	} else {
-		l, err = net.Listen("tcp", addr)
+
+		spec := svr.listenerSpecs[name]
+		l, err := svr.getListener(name, spec.network, spec.addr)
 		if err != nil {
+			return err
+		}
+
+		ln := newListener(l, svr)
+		if spec.tls {
+			ln.tlsConfig = &tls.Config{GetCertificate: svr.getCertificate}
+		}
+		svr.listeners[name] = ln
+		if name == "http" {
+			svr.ln = ln
+		}
+		if name == "admin" {
+			svr.AdminAddr = ln.Addr().String()
+		}
+	}
+
+	return nil
+}
+
+func (svr *RebirthServer) getListener(name, network, addr string) (l net.Listener, err error) {
+	if svr.RestartStrategy == StrategyReusePort {
+		return svr.listenReusePort(network, addr)
+	}
+
+	if svr.hasRebirth {
+		if idx, ok := socketOrderFromEnv()[name]; ok {
+			f := os.NewFile(uintptr(3+idx), name)
+			l, err = net.FileListener(f)
 			return
 		}
 	}
 
+	l, err = net.Listen(network, addr)
 	return
 }
 
+// soReusePort is SO_REUSEPORT's value on Linux: 0x200 on mips* and 0xf on
+// the other architectures. The syscall package only
+// defines this constant on a handful of GOARCH values (arm64, mips*,
+// ppc64*, ...) and omits it on linux/amd64 and linux/386, so it has to be
+// hardcoded rather than referenced as syscall.SO_REUSEPORT.
+var soReusePort = func() int {
+	if strings.HasPrefix(runtime.GOARCH, "mips") {
+		return 0x200
+	}
+	return 0xf
+}()
+
+// listenReusePort binds network/addr with SO_REUSEPORT and SO_REUSEADDR set
+// on the socket, so that under StrategyReusePort a forked child can bind
+// the same address again rather than inherit the parent's listener FD.
+func (svr *RebirthServer) listenReusePort(network, addr string) (net.Listener, error) {
+	lc := net.ListenConfig{
+		Control: func(_, _ string, c syscall.RawConn) error {
+			var sockErr error
+			err := c.Control(func(fd uintptr) {
+				sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, soReusePort, 1)
+				if sockErr != nil {
+					return
+				}
+				sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+			})
+			if err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+
+	return lc.Listen(context.Background(), network, addr)
+}
+
+// socketOrderFromEnv parses GO_REBIRTH_SOCKET_ORDER (e.g.
+// "http@:80,https@:443,admin@:9000") into a map of listener name to the
+// ExtraFiles offset it was passed at, in the order fork wrote them.
+func socketOrderFromEnv() map[string]int {
+	order := map[string]int{}
+
+	raw := os.Getenv(SOCKET_ORDER_ENV_KEY)
+	if raw == "" {
+		return order
+	}
+
+	for i, entry := range strings.Split(raw, ",") {
+		name := strings.SplitN(entry, "@", 2)[0]
+		order[name] = i
+	}
+
+	return order
+}
+
 func (svr *RebirthServer) setState(state uint8) {
+	svr.mutex.Lock()
 	svr.state = state
+	svr.mutex.Unlock()
+}
+
+// getState reads svr.state under svr.mutex, the same lock setState takes:
+// state is written from Serve's goroutine and from Shutdown (run by the
+// signal-handling goroutine or an admin handler) and read from the admin HTTP handlers' goroutines, so an unguarded read
+// is a genuine data race once an admin listener is in the mix.
+func (svr *RebirthServer) getState() uint8 {
+	svr.mutex.Lock()
+	defer svr.mutex.Unlock()
+	return svr.state
 }
 
 func (svr *RebirthServer) acceptProcessSign() {
@@ -118,13 +497,14 @@ func (svr *RebirthServer) acceptProcessSign() {
 
 	signal.Notify(
 		svr.sigChan,
-		syscall.SIGUSR1,
-		syscall.SIGUSR2,
+		svr.HookableSignals...,
 	)
 
 	pid := syscall.Getpid()
 	for {
 		sig = <-svr.sigChan
+		svr.runSignalHooks(PRE_SIGNAL, sig)
+		svr.notifySignalWaiters(sig)
 		switch sig {
 		case syscall.SIGUSR1:
 			log.Println(pid, "Received SIGHUP. forking.")
@@ -135,25 +515,129 @@ func (svr *RebirthServer) acceptProcessSign() {
 		case syscall.SIGUSR2:
 			log.Println(pid, "Received SIGINT.")
 			svr.shutdown()
+		case syscall.SIGHUP:
+			log.Println(pid, "Received SIGHUP. reloading TLS certificate.")
+			if err := svr.reloadCertificate(); err != nil {
+				log.Println("TLS reload err:", err)
+			}
 		default:
 			log.Printf("Received %v: nothing i care about...\n", sig)
 		}
+		svr.runSignalHooks(POST_SIGNAL, sig)
 	}
 }
 
+// fork hands every listener of every running server over to a freshly
+// exec'd child in one shot, so a single SIGUSR1 is enough even when this
+// process is running more than one RebirthServer. Under ModeDoubleExec it
+// also supervises the child and rolls the fork back if it never comes up.
 func (svr *RebirthServer) fork() (err error) {
-	svr.mutex.Lock()
+	// Register the readiness waiter before forkAll's cmd.Start(): the
+	// child can boot, bind, and send its readiness SIGUSR2 before a
+	// goroutine spawned after cmd.Start() gets scheduled, and a waiter
+	// registered too late would miss that signal and wait out the full
+	// timeout for a child that actually came up fine.
+	var ready <-chan struct{}
+	if svr.RestartMode == ModeDoubleExec {
+		ready = svr.waitForSignal(syscall.SIGUSR2)
+	}
+
+	cmd, err := forkAll()
+	if err != nil {
+		return err
+	}
 
-	if svr.hasRunFork {
-		errors.New("The process already forked...")
+	if svr.RestartMode == ModeDoubleExec {
+		go svr.superviseFork(cmd, ready)
 	}
-	svr.hasRunFork = true
-	svr.mutex.Unlock()
 
-	files := make([]*os.File, 1)
-	files[0] = svr.ln.(*RebirthListener).File()
+	return nil
+}
+
+// superviseFork waits for cmd to either report readiness on ready (via the
+// same SIGUSR2 handshake used on startup) or fail, and rolls hasRunFork
+// back so a later SIGUSR1 can retry the fork if cmd never comes up. ready
+// must have been registered via waitForSignal before cmd was started.
+func (svr *RebirthServer) superviseFork(cmd *exec.Cmd, ready <-chan struct{}) {
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	timeout := svr.DoubleExecTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
 
-	env := append(os.Environ(), fmt.Sprintf("%s=%s", REBIRTH_ENV_KEY, REBIRTH_TAG))
+	select {
+	case <-ready:
+		log.Println(syscall.Getpid(), "Child", cmd.Process.Pid, "reported ready.")
+	case err := <-exited:
+		log.Println(syscall.Getpid(), "Child", cmd.Process.Pid, "exited before becoming ready:", err)
+		svr.rollbackFork()
+	case <-time.After(timeout):
+		log.Println(syscall.Getpid(), "Child", cmd.Process.Pid, "did not become ready within", timeout)
+		svr.rollbackFork()
+	}
+}
+
+// rollbackFork clears hasRunFork on every running server, undoing what
+// forkAll set, so the next SIGUSR1 can retry.
+func (svr *RebirthServer) rollbackFork() {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	for _, s := range runningServers {
+		s.mutex.Lock()
+		s.hasRunFork = false
+		s.mutex.Unlock()
+	}
+}
+
+func forkAll() (*exec.Cmd, error) {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	var (
+		files     []*os.File
+		order     []string
+		forkedAny bool
+	)
+	for _, svr := range runningServers {
+		svr.mutex.Lock()
+		alreadyForked := svr.hasRunFork
+		svr.hasRunFork = true
+		svr.mutex.Unlock()
+		if alreadyForked {
+			continue
+		}
+		forkedAny = true
+
+		if svr.RestartStrategy == StrategyReusePort {
+			// The child rebinds each address itself with SO_REUSEPORT;
+			// no FD needs to be handed over for this server.
+			continue
+		}
+
+		for _, name := range svr.listenerOrder {
+			ln, ok := svr.listeners[name]
+			if !ok {
+				continue
+			}
+			files = append(files, ln.File())
+			order = append(order, fmt.Sprintf("%s@%s", name, ln.Addr().String()))
+		}
+	}
+
+	if !forkedAny {
+		return nil, errors.New("rebirth: no servers left to fork")
+	}
+
+	env := append(
+		os.Environ(),
+		fmt.Sprintf("%s=%s", REBIRTH_ENV_KEY, REBIRTH_TAG),
+		fmt.Sprintf("%s=%s", SOCKET_ORDER_ENV_KEY, strings.Join(order, ",")),
+	)
 
 	path := os.Args[0]
 	var args []string
@@ -166,31 +650,185 @@ func (svr *RebirthServer) fork() (err error) {
 	cmd.Stderr = os.Stderr
 	cmd.ExtraFiles = files
 	cmd.Env = env
-	err = cmd.Start()
+
+	err := cmd.Start()
 	if err != nil {
 		log.Fatalf("Restart: Failed to launch, error: %v", err)
 	}
 
-	return
+	return cmd, err
 }
 
+// shutdown is invoked from the signal loop and drains the server within
+// svr.HammerTime before forcing the remaining connections closed.
 func (svr *RebirthServer) shutdown() {
-	if svr.state != STATE_RUNNING {
-		return
+	ctx, cancel := context.WithTimeout(context.Background(), svr.HammerTime)
+	defer cancel()
+
+	if err := svr.Shutdown(ctx); err != nil {
+		log.Println(syscall.Getpid(), "Shutdown error:", err)
+	} else {
+		log.Println(syscall.Getpid(), "Server shutdown complete.")
 	}
+}
+
+// Shutdown gracefully drains svr: it stops accepting new connections and
+// waits for in-flight ones to finish until ctx is done, at which point any
+// connections still open are force-closed. It can be called directly by
+// operators, not only via SIGUSR2.
+func (svr *RebirthServer) Shutdown(ctx context.Context) error {
+	if svr.getState() != STATE_RUNNING {
+		return nil
+	}
+	svr.setState(STATE_SHUTTING_DOWN)
 	svr.SetKeepAlivesEnabled(false)
-	err := svr.ln.Close()
+
+	err := svr.Server.Shutdown(ctx)
 	if err != nil {
-		log.Println(syscall.Getpid(), "Listener.Close() error:", err)
-	} else {
-		log.Println(syscall.Getpid(), svr.ln.Addr(), "Listener closed.")
+		log.Println(syscall.Getpid(), "Graceful drain did not finish in time, hammering remaining conns:", err)
+		svr.hammer()
+	}
+
+	svr.setState(STATE_TERMINATE)
+	return err
+}
+
+// hammer force-closes every connection still tracked in svr.conns. It
+// copies the conns out from under the lock first: Conn.Close locks
+// connsMu itself to remove its own entry, and sync.Mutex isn't reentrant.
+func (svr *RebirthServer) hammer() {
+	svr.connsMu.Lock()
+	conns := make([]net.Conn, 0, len(svr.conns))
+	for c := range svr.conns {
+		conns = append(conns, c)
+	}
+	svr.connsMu.Unlock()
+
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+// StartAdmin registers an "admin" listener (defaulting to 127.0.0.1:0) that
+// serves an out-of-band control plane: /rebirth/state and /rebirth/conns
+// are read-only, /rebirth/restart and /rebirth/shutdown require
+// AdminToken. This lets operators drive fork/Shutdown without shell access
+// to send SIGUSR1/SIGUSR2.
+//
+// Like "http"/"https", the admin listener is registered into
+// listenerSpecs/listenerOrder, so Serve binds it (or inherits its file
+// descriptor across a fork). Under StrategyDupFD it rides along in
+// GO_REBIRTH_SOCKET_ORDER instead of being rebound to a new address on
+// every restart; under StrategyReusePort the child binds addr again, so a
+// ":0" port is not kept across a restart. Call
+// StartAdmin before Serve/ListenAndServe(TLS); svr.AdminAddr is set once
+// Serve has bound it.
+func (svr *RebirthServer) StartAdmin(addr string) error {
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rebirth/state", svr.handleAdminState)
+	mux.HandleFunc("/rebirth/conns", svr.handleAdminConns)
+	mux.HandleFunc("/rebirth/restart", svr.requireAdminToken(svr.handleAdminRestart))
+	mux.HandleFunc("/rebirth/shutdown", svr.requireAdminToken(svr.handleAdminShutdown))
+
+	return svr.registerListener("admin", addr, "tcp", mux)
+}
+
+func (svr *RebirthServer) handleAdminState(w http.ResponseWriter, r *http.Request) {
+	svr.mutex.Lock()
+	state := svr.state
+	listeners := make([]string, 0, len(svr.listenerOrder))
+	for _, name := range svr.listenerOrder {
+		if ln, ok := svr.listeners[name]; ok {
+			listeners = append(listeners, fmt.Sprintf("%s@%s", name, ln.Addr().String()))
+		}
+	}
+	svr.mutex.Unlock()
+
+	writeAdminJSON(w, map[string]interface{}{
+		"state":          stateName(state),
+		"pid":            syscall.Getpid(),
+		"ppid":           syscall.Getppid(),
+		"uptime_seconds": time.Since(svr.startedAt).Seconds(),
+		"listeners":      listeners,
+	})
+}
+
+func (svr *RebirthServer) handleAdminConns(w http.ResponseWriter, r *http.Request) {
+	svr.connsMu.Lock()
+	count := len(svr.conns)
+	svr.connsMu.Unlock()
+
+	writeAdminJSON(w, map[string]interface{}{"active_connections": count})
+}
+
+func (svr *RebirthServer) handleAdminRestart(w http.ResponseWriter, r *http.Request) {
+	if err := svr.fork(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAdminJSON(w, map[string]interface{}{"status": "forking"})
+}
+
+func (svr *RebirthServer) handleAdminShutdown(w http.ResponseWriter, r *http.Request) {
+	go svr.shutdown()
+	writeAdminJSON(w, map[string]interface{}{"status": "shutting_down"})
+}
+
+// requireAdminToken rejects requests that don't present a valid
+// HMAC-SHA256(AdminToken, request path) digest in X-Rebirth-Token.
+func (svr *RebirthServer) requireAdminToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !svr.validAdminToken(r) {
+			http.Error(w, "invalid or missing admin token", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (svr *RebirthServer) validAdminToken(r *http.Request) bool {
+	if svr.AdminToken == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(svr.AdminToken))
+	mac.Write([]byte(r.URL.Path))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(r.Header.Get("X-Rebirth-Token")), []byte(want))
+}
+
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(syscall.Getpid(), "Admin JSON encode error:", err)
+	}
+}
+
+func stateName(state uint8) string {
+	switch state {
+	case STATE_INIT:
+		return "STATE_INIT"
+	case STATE_RUNNING:
+		return "STATE_RUNNING"
+	case STATE_SHUTTING_DOWN:
+		return "STATE_SHUTTING_DOWN"
+	case STATE_TERMINATE:
+		return "STATE_TERMINATE"
+	default:
+		return "STATE_UNKNOWN"
 	}
 }
 
 type RebirthListener struct {
 	net.Listener
-	server  *RebirthServer
-	stopped bool
+	server    *RebirthServer
+	stopped   bool
+	tlsConfig *tls.Config
 }
 
 func newListener(l net.Listener, svr *RebirthServer) (ln *RebirthListener) {
@@ -209,8 +847,18 @@ func (ln *RebirthListener) Accept() (c net.Conn, err error) {
 	tc.SetKeepAlive(true)
 	tc.SetKeepAlivePeriod(3 * time.Minute)
 
+	var conn net.Conn = tc
+	if ln.tlsConfig != nil {
+		conn = tls.Server(tc, ln.tlsConfig)
+	}
+
 	ln.server.wg.Add(1)
-	c = NewConn(tc, ln.server)
+	c = NewConn(conn, ln.server)
+
+	ln.server.connsMu.Lock()
+	ln.server.conns[c] = struct{}{}
+	ln.server.connsMu.Unlock()
+
 	return c, nil
 }
 
@@ -231,6 +879,7 @@ func (ln *RebirthListener) Close() error {
 type Conn struct {
 	net.Conn
 	server *RebirthServer
+	closed bool
 }
 
 func NewConn(c net.Conn, s *RebirthServer) *Conn {
@@ -240,7 +889,20 @@ func NewConn(c net.Conn, s *RebirthServer) *Conn {
 	}
 }
 
+// Close is one-shot: hammer() and http.conn's own deferred cleanup can both
+// reach this from the raw socket being force-closed out from under an
+// in-flight Read/Write, and wg.Done/delete must only run once or the
+// WaitGroup counter goes negative.
 func (c *Conn) Close() error {
+	c.server.connsMu.Lock()
+	if c.closed {
+		c.server.connsMu.Unlock()
+		return nil
+	}
+	c.closed = true
+	delete(c.server.conns, c)
+	c.server.connsMu.Unlock()
+
 	c.server.wg.Done()
 	return c.Conn.Close()
 }
@@ -0,0 +1,107 @@
+package rebirth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSocketOrderFromEnv(t *testing.T) {
+	t.Setenv(SOCKET_ORDER_ENV_KEY, "http@:80,https@:443,admin@:9000")
+
+	got := socketOrderFromEnv()
+	want := map[string]int{"http": 0, "https": 1, "admin": 2}
+
+	if len(got) != len(want) {
+		t.Fatalf("socketOrderFromEnv() = %v, want %v", got, want)
+	}
+	for name, idx := range want {
+		if got[name] != idx {
+			t.Errorf("socketOrderFromEnv()[%q] = %d, want %d", name, got[name], idx)
+		}
+	}
+}
+
+func TestSocketOrderFromEnvEmpty(t *testing.T) {
+	t.Setenv(SOCKET_ORDER_ENV_KEY, "")
+
+	if got := socketOrderFromEnv(); len(got) != 0 {
+		t.Errorf("socketOrderFromEnv() = %v, want empty", got)
+	}
+}
+
+func TestValidAdminToken(t *testing.T) {
+	svr := NewServer(":0", nil)
+	svr.AdminToken = "s3cr3t"
+
+	req := httptest.NewRequest(http.MethodPost, "/rebirth/restart", nil)
+	req.Header.Set("X-Rebirth-Token", "bogus")
+	if svr.validAdminToken(req) {
+		t.Error("validAdminToken() = true for a bogus token, want false")
+	}
+
+	mac := hmac.New(sha256.New, []byte(svr.AdminToken))
+	mac.Write([]byte(req.URL.Path))
+	req.Header.Set("X-Rebirth-Token", hex.EncodeToString(mac.Sum(nil)))
+	if !svr.validAdminToken(req) {
+		t.Error("validAdminToken() = false for a correctly signed token, want true")
+	}
+}
+
+func TestValidAdminTokenDisabledWithoutAdminToken(t *testing.T) {
+	svr := NewServer(":0", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/rebirth/restart", nil)
+	req.Header.Set("X-Rebirth-Token", "anything")
+	if svr.validAdminToken(req) {
+		t.Error("validAdminToken() = true with AdminToken unset, want false")
+	}
+}
+
+func TestWaitForSignalFiresOnce(t *testing.T) {
+	svr := NewServer(":0", nil)
+
+	ch := svr.waitForSignal(syscall.SIGUSR2)
+	svr.notifySignalWaiters(syscall.SIGUSR2)
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("waitForSignal channel never fired")
+	}
+
+	svr.mutex.Lock()
+	_, stillRegistered := svr.sigWaiters[syscall.SIGUSR2]
+	svr.mutex.Unlock()
+	if stillRegistered {
+		t.Error("sigWaiters still holds an entry after notifySignalWaiters fired, want it cleared")
+	}
+}
+
+func TestNotifySignalWaitersNoWaiters(t *testing.T) {
+	svr := NewServer(":0", nil)
+
+	// Must not panic or block when nothing is waiting on the signal.
+	svr.notifySignalWaiters(syscall.SIGUSR1)
+}
+
+func TestStateName(t *testing.T) {
+	cases := map[uint8]string{
+		STATE_INIT:          "STATE_INIT",
+		STATE_RUNNING:       "STATE_RUNNING",
+		STATE_SHUTTING_DOWN: "STATE_SHUTTING_DOWN",
+		STATE_TERMINATE:     "STATE_TERMINATE",
+		255:                 "STATE_UNKNOWN",
+	}
+
+	for state, want := range cases {
+		if got := stateName(state); got != want {
+			t.Errorf("stateName(%d) = %q, want %q", state, got, want)
+		}
+	}
+}